@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/sdk/metric/internal"
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// cumulativeHistStream holds the running cumulative state for a single
+// attribute set accumulated from a sequence of delta HistogramDataPoints.
+type cumulativeHistStream struct {
+	start time.Time
+	end   time.Time
+
+	buckets   *buckets
+	hasMinMax bool
+}
+
+// deltaToCumulativeHistogram accumulates pre-aggregated delta
+// metricdata.HistogramDataPoint measurements into a cumulative series. It is
+// used to re-export delta-temporality histograms, such as those produced by
+// instrumentation that cannot maintain cumulative state itself, with
+// cumulative temporality.
+type deltaToCumulativeHistogram[N int64 | float64] struct {
+	noMinMax bool
+	bounds   []float64
+
+	valuesMu sync.Mutex
+	values   map[attribute.Set]*cumulativeHistStream
+}
+
+// NewDeltaToCumulativeHistogram returns an aggregator that accumulates delta
+// metricdata.HistogramDataPoint values, keyed by attribute set, into a
+// cumulative metricdata.Histogram. Unlike the Aggregator[N] implementations
+// in this package, it is fed pre-aggregated points via AggregatePoint rather
+// than raw measurements.
+func NewDeltaToCumulativeHistogram[N int64 | float64](cfg aggregation.ExplicitBucketHistogram) *deltaToCumulativeHistogram[N] {
+	bounds := make([]float64, len(cfg.Boundaries))
+	copy(bounds, cfg.Boundaries)
+	sort.Float64s(bounds)
+
+	return &deltaToCumulativeHistogram[N]{
+		noMinMax: cfg.NoMinMax,
+		bounds:   bounds,
+		values:   make(map[attribute.Set]*cumulativeHistStream),
+	}
+}
+
+// AggregatePoint merges a delta metricdata.HistogramDataPoint into the
+// cumulative state for its attribute set.
+//
+// If dp.Bounds does not match the configured boundaries, dp is re-bucketed
+// against them before being merged.
+//
+// If dp.StartTime is before the stored StartTime for its attribute set, the
+// accumulator for that attribute set is treated as having reset (e.g. the
+// source instrument restarted) and is replaced by dp.
+func (s *deltaToCumulativeHistogram[N]) AggregatePoint(dp metricdata.HistogramDataPoint) {
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+
+	stream, ok := s.values[dp.Attributes]
+	if ok && dp.StartTime.Before(stream.start) {
+		// The source stream restarted (counter reset); start over rather
+		// than mixing pre- and post-reset counts.
+		ok = false
+	}
+	if !ok {
+		stream = &cumulativeHistStream{
+			start:   dp.StartTime,
+			buckets: newBuckets(len(s.bounds) + 1),
+		}
+		s.values[dp.Attributes] = stream
+	}
+
+	counts := rebucket(dp, s.bounds)
+
+	b := stream.buckets
+	for i, c := range counts {
+		b.counts[i] += c
+	}
+	b.count += dp.Count
+	b.sum += dp.Sum
+
+	if !s.noMinMax {
+		if dp.Min != nil {
+			min := *dp.Min
+			if !stream.hasMinMax || min < b.min {
+				b.min = min
+			}
+		}
+		if dp.Max != nil {
+			max := *dp.Max
+			if !stream.hasMinMax || max > b.max {
+				b.max = max
+			}
+		}
+		if dp.Min != nil || dp.Max != nil {
+			stream.hasMinMax = true
+		}
+	}
+
+	stream.end = dp.Time
+}
+
+// rebucket returns dp's bucket counts re-binned against bounds. If dp.Bounds
+// already matches bounds, its BucketCounts are returned unmodified.
+func rebucket(dp metricdata.HistogramDataPoint, bounds []float64) []uint64 {
+	if boundsEqual(dp.Bounds, bounds) {
+		return dp.BucketCounts
+	}
+
+	out := make([]uint64, len(bounds)+1)
+	lower := 0.0
+	for i, c := range dp.BucketCounts {
+		// Approximate the bucket's contents as falling at its upper
+		// (or, for the final overflow bucket, lower) edge.
+		v := lower
+		if i < len(dp.Bounds) {
+			v = dp.Bounds[i]
+		}
+		out[sort.SearchFloat64s(bounds, v)] += c
+		if i < len(dp.Bounds) {
+			lower = dp.Bounds[i]
+		}
+	}
+	return out
+}
+
+func boundsEqual(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Aggregation returns the accumulated state as a cumulative
+// metricdata.Histogram. The accumulated state is not reset by this call;
+// later AggregatePoint calls keep adding to it. The returned data points hold
+// copies of that state.
+func (s *deltaToCumulativeHistogram[N]) Aggregation() metricdata.Aggregation {
+	s.valuesMu.Lock()
+	defer s.valuesMu.Unlock()
+
+	if len(s.values) == 0 {
+		return nil
+	}
+
+	h := metricdata.Histogram{
+		Temporality: metricdata.CumulativeTemporality,
+		DataPoints:  make([]metricdata.HistogramDataPoint, 0, len(s.values)),
+	}
+	for a, stream := range s.values {
+		b := stream.buckets
+		counts := make([]uint64, len(b.counts))
+		copy(counts, b.counts)
+
+		bounds := make([]float64, len(s.bounds))
+		copy(bounds, s.bounds)
+
+		hdp := metricdata.HistogramDataPoint{
+			Attributes:   a,
+			StartTime:    stream.start,
+			Time:         stream.end,
+			Count:        b.count,
+			Bounds:       bounds,
+			BucketCounts: counts,
+			Sum:          b.sum,
+		}
+		if stream.hasMinMax {
+			min, max := b.min, b.max
+			hdp.Min = &min
+			hdp.Max = &max
+		}
+		h.DataPoints = append(h.DataPoints, hdp)
+	}
+	return h
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/sdk/metric/internal"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestDeltaToCumulativeHistogramMultiStream(t *testing.T) {
+	a := NewDeltaToCumulativeHistogram[int64](histConf)
+
+	start := now()
+	mid := start.Add(time.Second)
+	end := mid.Add(time.Second)
+
+	a.AggregatePoint(hPoint(alice, 2, 1))
+	a.AggregatePoint(hPoint(bob, 6, 1))
+
+	// Accumulate a second delta point for alice; the cumulative output
+	// should fold it into the running totals rather than replace them.
+	second := hPoint(alice, 2, 1)
+	second.StartTime = mid
+	second.Time = end
+	a.AggregatePoint(second)
+
+	got := a.Aggregation().(metricdata.Histogram)
+	require.Len(t, got.DataPoints, 2)
+
+	byAttrs := make(map[string]metricdata.HistogramDataPoint, len(got.DataPoints))
+	for _, dp := range got.DataPoints {
+		byAttrs[dp.Attributes.Encoded(nil)] = dp
+	}
+
+	aliceDP := byAttrs[alice.Encoded(nil)]
+	assert.Equal(t, uint64(2), aliceDP.Count)
+	assert.Equal(t, 4.0, aliceDP.Sum)
+	assert.Equal(t, []uint64{0, 2, 0}, aliceDP.BucketCounts)
+	require.NotNil(t, aliceDP.Min)
+	require.NotNil(t, aliceDP.Max)
+	assert.Equal(t, 2.0, *aliceDP.Min)
+	assert.Equal(t, 2.0, *aliceDP.Max)
+
+	bobDP := byAttrs[bob.Encoded(nil)]
+	assert.Equal(t, uint64(1), bobDP.Count)
+	assert.Equal(t, 6.0, bobDP.Sum)
+
+	// The accumulated state persists across Aggregation calls.
+	again := a.Aggregation().(metricdata.Histogram)
+	require.Len(t, again.DataPoints, 2)
+}
+
+func TestDeltaToCumulativeHistogramReset(t *testing.T) {
+	a := NewDeltaToCumulativeHistogram[int64](histConf)
+
+	start := now()
+	earlier := start.Add(-time.Second)
+
+	first := hPoint(alice, 2, 1)
+	first.StartTime = start
+	a.AggregatePoint(first)
+
+	reset := hPoint(alice, 6, 1)
+	reset.StartTime = earlier
+	a.AggregatePoint(reset)
+
+	got := a.Aggregation().(metricdata.Histogram)
+	require.Len(t, got.DataPoints, 1)
+	dp := got.DataPoints[0]
+
+	// The pre-reset point should have been discarded rather than merged.
+	assert.Equal(t, uint64(1), dp.Count)
+	assert.Equal(t, 6.0, dp.Sum)
+	assert.Equal(t, earlier, dp.StartTime)
+}
+
+func TestDeltaToCumulativeHistogramBoundsMismatch(t *testing.T) {
+	a := NewDeltaToCumulativeHistogram[int64](aggregation.ExplicitBucketHistogram{
+		Boundaries: []float64{2, 4},
+	})
+
+	dp := hPoint(alice, 1, 1) // bucketed against []float64{1, 5}
+	a.AggregatePoint(dp)
+
+	got := a.Aggregation().(metricdata.Histogram)
+	require.Len(t, got.DataPoints, 1)
+	// A value of 1 is in the first bucket of both [1, 5] and [2, 4], so
+	// re-bucketing lands it in the same, first bucket.
+	assert.Equal(t, []uint64{1, 0, 0}, got.DataPoints[0].BucketCounts)
+	assert.Equal(t, []float64{2, 4}, got.DataPoints[0].Bounds)
+}
+
+func TestDeltaToCumulativeHistogramNoMinMax(t *testing.T) {
+	a := NewDeltaToCumulativeHistogram[int64](aggregation.ExplicitBucketHistogram{
+		Boundaries: bounds,
+		NoMinMax:   true,
+	})
+
+	a.AggregatePoint(hPoint(alice, 2, 1))
+
+	got := a.Aggregation().(metricdata.Histogram)
+	require.Len(t, got.DataPoints, 1)
+	assert.Nil(t, got.DataPoints[0].Min)
+	assert.Nil(t, got.DataPoints[0].Max)
+}
+
+func TestDeltaToCumulativeHistogramEmpty(t *testing.T) {
+	a := NewDeltaToCumulativeHistogram[int64](histConf)
+	assert.Nil(t, a.Aggregation())
+}